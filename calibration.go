@@ -0,0 +1,232 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Calibration maps a measured value to the corrected value.
+type Calibration interface {
+	Apply(value float64) float64
+}
+
+// LinearCalibration corrects a measured value with a gain and an offset:
+// corrected = Gain * measured + Offset
+//
+// The zero value maps every value to zero. Use NewLinearCalibration for the identity.
+type LinearCalibration struct {
+	Gain   float64
+	Offset float64
+}
+
+// NewLinearCalibration returns a calibration that does not change the values.
+func NewLinearCalibration() LinearCalibration {
+	return LinearCalibration{Gain: 1.0, Offset: 0.0}
+}
+
+// Apply returns the corrected value for the given measured value.
+func (c LinearCalibration) Apply(value float64) float64 {
+	return c.Gain*value + c.Offset
+}
+
+// TwoPointCalibration derives the linear calibration that maps the two measured
+// values to their reference values, e.g. the readings of a hygrometer in
+// saturated salt solutions of magnesium chloride (33 %) and sodium chloride (75 %).
+func TwoPointCalibration(reference1, measured1, reference2, measured2 float64) (LinearCalibration, error) {
+	if measured1 == measured2 {
+		return LinearCalibration{}, fmt.Errorf(
+			"both calibration points have the same measured value %g", measured1)
+	}
+	gain := (reference2 - reference1) / (measured2 - measured1)
+	return LinearCalibration{Gain: gain, Offset: reference1 - gain*measured1}, nil
+}
+
+// parseCalibrationNumber parses a finite number of a calibration option.
+func parseCalibrationNumber(value string) (float64, error) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("'%s' is not a finite number", strings.TrimSpace(value))
+	}
+	return number, nil
+}
+
+// parseCalibrationPoints parses calibration points in the form
+// "reference:measured,reference:measured,...".
+func parseCalibrationPoints(value string) ([]CalibrationPoint, error) {
+	var points []CalibrationPoint
+	for _, point := range strings.Split(value, ",") {
+		pair := strings.Split(point, ":")
+		if len(pair) != 2 {
+			return nil, fmt.Errorf(
+				"expected calibration point in the form 'reference:measured', but got '%s'", point)
+		}
+		reference, err := parseCalibrationNumber(pair[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid calibration point '%s': %v", point, err)
+		}
+		measured, err := parseCalibrationNumber(pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid calibration point '%s': %v", point, err)
+		}
+		points = append(points, CalibrationPoint{Reference: reference, Measured: measured})
+	}
+	return points, nil
+}
+
+// ParseTwoPointCalibration parses two calibration points in the form
+// "reference:measured,reference:measured" (e.g. "33:32.1,75:76.8") and
+// derives the linear calibration from them.
+func ParseTwoPointCalibration(value string) (LinearCalibration, error) {
+	if len(strings.Split(value, ",")) != 2 {
+		return LinearCalibration{}, fmt.Errorf(
+			"expected two calibration points separated by comma, but got '%s'", value)
+	}
+	points, err := parseCalibrationPoints(value)
+	if err != nil {
+		return LinearCalibration{}, err
+	}
+	return TwoPointCalibration(points[0].Reference, points[0].Measured, points[1].Reference, points[1].Measured)
+}
+
+// PolynomialCalibration corrects a measured value x with the polynomial
+// corrected = c[0] + c[1] * x + c[2] * x² + ...
+//
+// A polynomial without coefficients does not change the values.
+type PolynomialCalibration []float64
+
+// ParsePolynomialCalibration parses the coefficients of a polynomial calibration
+// separated by comma, starting with the constant term (e.g. "-1.5,1.02,0.0003").
+func ParsePolynomialCalibration(value string) (PolynomialCalibration, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("no polynomial coefficients specified")
+	}
+	var coefficients PolynomialCalibration
+	for _, number := range strings.Split(value, ",") {
+		coefficient, err := parseCalibrationNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("invalid polynomial coefficient: %v", err)
+		}
+		coefficients = append(coefficients, coefficient)
+	}
+	return coefficients, nil
+}
+
+// Apply returns the corrected value for the given measured value.
+func (c PolynomialCalibration) Apply(value float64) float64 {
+	if len(c) == 0 {
+		return value
+	}
+	corrected := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		corrected = corrected*value + c[i]
+	}
+	return corrected
+}
+
+// CalibrationPoint is a measured value and its corresponding reference value.
+type CalibrationPoint struct {
+	Reference float64
+	Measured  float64
+}
+
+// TableCalibration corrects a measured value by linear interpolation between
+// the calibration points. Values outside the table are extrapolated from the
+// first or last segment.
+type TableCalibration struct {
+	points []CalibrationPoint
+}
+
+// NewTableCalibration creates a piecewise linear calibration from at least two
+// finite calibration points with distinct measured values. The points can be
+// passed in any order.
+func NewTableCalibration(points []CalibrationPoint) (*TableCalibration, error) {
+	if len(points) < 2 {
+		return nil, fmt.Errorf("expected at least two calibration points, but got %d", len(points))
+	}
+	for _, point := range points {
+		if math.IsNaN(point.Reference) || math.IsInf(point.Reference, 0) ||
+			math.IsNaN(point.Measured) || math.IsInf(point.Measured, 0) {
+			return nil, fmt.Errorf("calibration point %g:%g is not finite", point.Reference, point.Measured)
+		}
+	}
+	sorted := make([]CalibrationPoint, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Measured < sorted[j].Measured })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Measured == sorted[i-1].Measured {
+			return nil, fmt.Errorf(
+				"multiple calibration points have the same measured value %g", sorted[i].Measured)
+		}
+	}
+	return &TableCalibration{points: sorted}, nil
+}
+
+// ParseTableCalibration parses at least two calibration points in the form
+// "reference:measured,reference:measured,..." (e.g. "11:15,33:35,75:80") and
+// creates a piecewise linear calibration from them.
+func ParseTableCalibration(value string) (*TableCalibration, error) {
+	points, err := parseCalibrationPoints(value)
+	if err != nil {
+		return nil, err
+	}
+	return NewTableCalibration(points)
+}
+
+// Apply returns the corrected value for the given measured value.
+func (c *TableCalibration) Apply(value float64) float64 {
+	// Index of the first point of the segment to interpolate in
+	i := sort.Search(len(c.points)-2, func(i int) bool { return c.points[i+1].Measured >= value })
+	lower, upper := c.points[i], c.points[i+1]
+	return lower.Reference + (value-lower.Measured)*
+		(upper.Reference-lower.Reference)/(upper.Measured-lower.Measured)
+}
+
+// FitLinearCalibration calculates the calibration that maps the measured values
+// best to the co-sampled reference values using the least squares method. If
+// fitGain is false, only the offset is fitted and the gain stays at 1.
+func FitLinearCalibration(reference []float64, measured []float64, fitGain bool) (LinearCalibration, error) {
+	if len(reference) != len(measured) {
+		return LinearCalibration{}, fmt.Errorf(
+			"got %d reference values, but %d measured values", len(reference), len(measured))
+	}
+	if len(measured) == 0 {
+		return LinearCalibration{}, fmt.Errorf("no values to fit calibration to")
+	}
+
+	n := float64(len(measured))
+	var meanReference, meanMeasured float64
+	for i := range measured {
+		meanReference += reference[i] / n
+		meanMeasured += measured[i] / n
+	}
+	if !fitGain {
+		return LinearCalibration{Gain: 1.0, Offset: meanReference - meanMeasured}, nil
+	}
+
+	// Check this explicitly: the mean is not exact and the variance would not be zero.
+	constant := true
+	for _, value := range measured {
+		constant = constant && value == measured[0]
+	}
+	if constant {
+		return LinearCalibration{}, fmt.Errorf(
+			"cannot fit gain, because all measured values are %g", measured[0])
+	}
+
+	var covariance, variance float64
+	for i := range measured {
+		covariance += (measured[i] - meanMeasured) * (reference[i] - meanReference)
+		variance += (measured[i] - meanMeasured) * (measured[i] - meanMeasured)
+	}
+	gain := covariance / variance
+	return LinearCalibration{Gain: gain, Offset: meanReference - gain*meanMeasured}, nil
+}
@@ -0,0 +1,251 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearCalibrationApply(t *testing.T) {
+	tests := []struct {
+		gain     float64
+		offset   float64
+		value    float64
+		expected float64
+	}{
+		{1.0, 0.0, 21.5, 21.5},
+		{1.0, -1.5, 21.5, 20.0},
+		{1.05, 0.0, 60.0, 63.0},
+		{0.98, 2.0, 50.0, 51.0},
+	}
+
+	for _, test := range tests {
+		calibration := LinearCalibration{Gain: test.gain, Offset: test.offset}
+		corrected := calibration.Apply(test.value)
+		if math.Abs(corrected-test.expected) > 1e-9 {
+			t.Errorf(
+				"Calibration with gain %f and offset %f for %f was incorrect, got: %f, want: %f.",
+				test.gain, test.offset, test.value, corrected, test.expected)
+		}
+	}
+}
+
+func TestNewLinearCalibration(t *testing.T) {
+	calibration := NewLinearCalibration()
+	if corrected := calibration.Apply(42.0); corrected != 42.0 {
+		t.Errorf("Identity calibration changed 42.000000 to %f.", corrected)
+	}
+}
+
+func TestParseTwoPointCalibration(t *testing.T) {
+	tests := []struct {
+		value    string
+		measured float64
+		expected float64
+	}{
+		{"33:32.1,75:76.8", 32.1, 33.0},
+		{"33:32.1,75:76.8", 76.8, 75.0},
+		{"33:32.1,75:76.8", 54.45, 54.0},
+		{" 75 : 73 , 33 : 35 ", 54.0, 54.0},
+		{"0:1,100:101", 50.0, 49.0},
+	}
+
+	for _, test := range tests {
+		calibration, err := ParseTwoPointCalibration(test.value)
+		if err != nil {
+			t.Errorf("Parsing calibration '%s' failed: %v", test.value, err)
+			continue
+		}
+		corrected := calibration.Apply(test.measured)
+		if math.Abs(corrected-test.expected) > 1e-9 {
+			t.Errorf(
+				"Calibration '%s' for %f was incorrect, got: %f, want: %f.",
+				test.value, test.measured, corrected, test.expected)
+		}
+	}
+}
+
+func TestParseTwoPointCalibrationInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"33:32.1",
+		"33:32.1,75:76.8,90:91",
+		"33:32.1,75",
+		"33:32.1,75:76.8:1",
+		"33:abc,75:76.8",
+		"33:50,75:50",
+		"NaN:1,2:3",
+		"1:Inf,2:3",
+		"1:2,-Inf:3",
+		"1:2,3:nan",
+	}
+
+	for _, value := range tests {
+		if _, err := ParseTwoPointCalibration(value); err == nil {
+			t.Errorf("Parsing invalid calibration '%s' did not fail.", value)
+		}
+	}
+}
+
+func TestPolynomialCalibrationApply(t *testing.T) {
+	tests := []struct {
+		coefficients PolynomialCalibration
+		value        float64
+		expected     float64
+	}{
+		{PolynomialCalibration{}, 12.0, 12.0},
+		{PolynomialCalibration{-1.5}, 12.0, -1.5},
+		{PolynomialCalibration{-1.5, 1.0}, 12.0, 10.5},
+		{PolynomialCalibration{1.0, 2.0, 0.5}, 4.0, 17.0},
+		{PolynomialCalibration{0.0, 0.0, 0.0, 1.0}, -2.0, -8.0},
+	}
+
+	for _, test := range tests {
+		corrected := test.coefficients.Apply(test.value)
+		if math.Abs(corrected-test.expected) > 1e-9 {
+			t.Errorf(
+				"Polynomial calibration %v for %f was incorrect, got: %f, want: %f.",
+				test.coefficients, test.value, corrected, test.expected)
+		}
+	}
+}
+
+func TestParsePolynomialCalibration(t *testing.T) {
+	calibration, err := ParsePolynomialCalibration("1, 2,0.5")
+	if err != nil {
+		t.Fatalf("Parsing polynomial calibration failed: %v", err)
+	}
+	if corrected := calibration.Apply(4.0); math.Abs(corrected-17.0) > 1e-9 {
+		t.Errorf("Polynomial calibration %v for 4.000000 was incorrect, got: %f, want: 17.000000.",
+			calibration, corrected)
+	}
+}
+
+func TestParsePolynomialCalibrationInvalid(t *testing.T) {
+	tests := []string{"", " ", "1,", "1,abc", "NaN", "1,Inf"}
+
+	for _, value := range tests {
+		if _, err := ParsePolynomialCalibration(value); err == nil {
+			t.Errorf("Parsing invalid polynomial calibration '%s' did not fail.", value)
+		}
+	}
+}
+
+func TestTableCalibrationApply(t *testing.T) {
+	calibration, err := NewTableCalibration([]CalibrationPoint{
+		{Reference: 75.0, Measured: 80.0},
+		{Reference: 11.0, Measured: 15.0},
+		{Reference: 33.0, Measured: 35.0},
+	})
+	if err != nil {
+		t.Fatalf("Creating table calibration failed: %v", err)
+	}
+
+	tests := []struct {
+		value    float64
+		expected float64
+	}{
+		{15.0, 11.0},
+		{25.0, 22.0},
+		{35.0, 33.0},
+		{57.5, 54.0},
+		{80.0, 75.0},
+		{5.0, 0.0},
+		{90.0, 84.333333},
+	}
+
+	for _, test := range tests {
+		corrected := calibration.Apply(test.value)
+		if math.Abs(corrected-test.expected) > 1e-6 {
+			t.Errorf(
+				"Table calibration for %f was incorrect, got: %f, want: %f.",
+				test.value, corrected, test.expected)
+		}
+	}
+}
+
+func TestNewTableCalibrationInvalid(t *testing.T) {
+	tests := [][]CalibrationPoint{
+		nil,
+		{{Reference: 33.0, Measured: 35.0}},
+		{{Reference: 33.0, Measured: 35.0}, {Reference: 34.0, Measured: 35.0}},
+		{{Reference: 1.0, Measured: math.NaN()}, {Reference: 2.0, Measured: 3.0}, {Reference: 4.0, Measured: 5.0}},
+		{{Reference: math.NaN(), Measured: 1.0}, {Reference: 2.0, Measured: 3.0}},
+		{{Reference: 1.0, Measured: math.Inf(1)}, {Reference: 2.0, Measured: 3.0}},
+	}
+
+	for _, points := range tests {
+		if _, err := NewTableCalibration(points); err == nil {
+			t.Errorf("Creating table calibration from %v did not fail.", points)
+		}
+	}
+}
+
+func TestParseTableCalibration(t *testing.T) {
+	calibration, err := ParseTableCalibration("75:80, 11:15,33:35")
+	if err != nil {
+		t.Fatalf("Parsing table calibration failed: %v", err)
+	}
+	if corrected := calibration.Apply(57.5); math.Abs(corrected-54.0) > 1e-9 {
+		t.Errorf("Table calibration for 57.500000 was incorrect, got: %f, want: 54.000000.", corrected)
+	}
+}
+
+func TestParseTableCalibrationInvalid(t *testing.T) {
+	tests := []string{"", "33:35", "33:35,75", "33:35,75:80:1", "33:35,abc:80", "33:35,75:NaN", "33:35,34:35"}
+
+	for _, value := range tests {
+		if _, err := ParseTableCalibration(value); err == nil {
+			t.Errorf("Parsing invalid table calibration '%s' did not fail.", value)
+		}
+	}
+}
+
+func TestFitLinearCalibration(t *testing.T) {
+	reference := []float64{20.0, 21.0, 22.0, 23.0}
+	tests := []struct {
+		measured []float64
+		fitGain  bool
+		gain     float64
+		offset   float64
+	}{
+		{[]float64{21.5, 22.5, 23.5, 24.5}, false, 1.0, -1.5},
+		{[]float64{21.4, 22.6, 23.4, 24.6}, false, 1.0, -1.5},
+		{[]float64{21.5, 22.5, 23.5, 24.5}, true, 1.0, -1.5},
+		{[]float64{10.0, 12.0, 14.0, 16.0}, true, 0.5, 15.0},
+	}
+
+	for _, test := range tests {
+		calibration, err := FitLinearCalibration(reference, test.measured, test.fitGain)
+		if err != nil {
+			t.Errorf("Fitting calibration to %v failed: %v", test.measured, err)
+			continue
+		}
+		if math.Abs(calibration.Gain-test.gain) > 1e-9 || math.Abs(calibration.Offset-test.offset) > 1e-9 {
+			t.Errorf(
+				"Fitted calibration for %v was incorrect, got: gain %f, offset %f, want: gain %f, offset %f.",
+				test.measured, calibration.Gain, calibration.Offset, test.gain, test.offset)
+		}
+	}
+}
+
+func TestFitLinearCalibrationInvalid(t *testing.T) {
+	tests := []struct {
+		reference []float64
+		measured  []float64
+	}{
+		{nil, nil},
+		{[]float64{20.0, 21.0}, []float64{20.0}},
+		{[]float64{20.0, 21.0}, []float64{22.0, 22.0}},
+		{[]float64{20.0, 21.0, 22.0, 23.0, 24.0}, []float64{21.3, 21.3, 21.3, 21.3, 21.3}},
+		{[]float64{20.0, 21.0, 22.0}, []float64{0.3, 0.3, 0.3}},
+	}
+
+	for _, test := range tests {
+		if _, err := FitLinearCalibration(test.reference, test.measured, true); err == nil {
+			t.Errorf("Fitting calibration to %v did not fail.", test.measured)
+		}
+	}
+}
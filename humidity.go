@@ -18,6 +18,84 @@ func saturationVaporPressureWater(tempCelsius float64) float64 {
 	return 6.1121 * math.Exp((18.678-tempCelsius/234.5)*(tempCelsius/(257.14+tempCelsius)))
 }
 
+// HumidityConverter converts between relative and absolute humidity using the
+// configured saturation vapour pressure formula. The zero value uses the Arden
+// Buck equation over water.
+type HumidityConverter struct {
+	Formula SaturationVaporPressureFormula
+	// Use the saturation vapour pressure over ice for temperatures below 0° C
+	OverIce bool
+}
+
+// SaturationVaporPressure calculates the saturation vapour pressure in hPa.
+func (c HumidityConverter) SaturationVaporPressure(tempCelsius float64) float64 {
+	if c.OverIce && tempCelsius < 0 {
+		return c.Formula.Ice(tempCelsius)
+	}
+	return c.Formula.Water(tempCelsius)
+}
+
+// Relative2AbsoluteHumidity calculates the absolute humidity in g/m³ for a given
+// relative humidity and temperature in Celsius. See the package level function
+// Relative2AbsoluteHumidity for the derivation of the formula.
+func (c HumidityConverter) Relative2AbsoluteHumidity(relativeHumidity float64, tempCelsius float64) float64 {
+	tempKelvin := tempCelsius + 273.15
+	return 1000 * relativeHumidity * c.SaturationVaporPressure(tempCelsius) / (gasConstantWater * tempKelvin)
+}
+
+// Absolute2RelativeHumidity calculates the relative humidity in percent for a given
+// absolute humidity in g/m³ and temperature in Celsius.
+func (c HumidityConverter) Absolute2RelativeHumidity(absoluteHumidity float64, tempCelsius float64) float64 {
+	tempKelvin := tempCelsius + 273.15
+	return absoluteHumidity * gasConstantWater * tempKelvin / (1000 * c.SaturationVaporPressure(tempCelsius))
+}
+
+// CorrectRelativeHumidity recalculates the relative humidity in percent that was
+// measured at the sensor temperature for the corrected temperature (both in Celsius).
+// See the package level function CorrectRelativeHumidity for details.
+func (c HumidityConverter) CorrectRelativeHumidity(relativeHumidity float64, sensorTempCelsius float64, tempCelsius float64) float64 {
+	absoluteHumidity := c.Relative2AbsoluteHumidity(relativeHumidity, sensorTempCelsius)
+	return math.Min(100, c.Absolute2RelativeHumidity(absoluteHumidity, tempCelsius))
+}
+
+// DewPoint calculates the dew point in Celsius for a given relative humidity and
+// temperature in Celsius. With OverIce it is the frost point below 0° C. The Arden
+// Buck equation over water is solved analytically (see the package level function
+// DewPoint), the other formulas are solved numerically by bisection.
+func (c HumidityConverter) DewPoint(relativeHumidity float64, tempCelsius float64) float64 {
+	if c.Formula == ArdenBuck && !c.OverIce {
+		return DewPoint(relativeHumidity, tempCelsius)
+	}
+	if relativeHumidity <= 0 {
+		return math.Inf(-1)
+	}
+	vaporPressure := relativeHumidity / 100 * c.SaturationVaporPressure(tempCelsius)
+	if math.IsNaN(vaporPressure) || math.IsInf(vaporPressure, 1) {
+		return math.NaN()
+	}
+	// The saturation vapour pressure increases with the temperature.
+	lower, upper := -100.0, tempCelsius
+	for c.SaturationVaporPressure(upper) < vaporPressure {
+		lower, upper = upper, upper+50
+	}
+	for upper-lower > 1e-9 {
+		middle := (lower + upper) / 2
+		if c.SaturationVaporPressure(middle) < vaporPressure {
+			lower = middle
+		} else {
+			upper = middle
+		}
+	}
+	return (lower + upper) / 2
+}
+
+// DewPointDepression calculates the difference in Kelvin between a surface
+// temperature and the dew point of the air (given as relative humidity and air
+// temperature in Celsius). See the package level function DewPointDepression.
+func (c HumidityConverter) DewPointDepression(surfaceTempCelsius float64, relativeHumidity float64, airTempCelsius float64) float64 {
+	return surfaceTempCelsius - c.DewPoint(relativeHumidity, airTempCelsius)
+}
+
 // Relative2AbsoluteHumidity calculates the absolute humidity in g/m³ for a given
 // relative humidity and temperature in Celsius.
 //
@@ -37,7 +115,62 @@ func saturationVaporPressureWater(tempCelsius float64) float64 {
 // RH: relative humidity
 // T: temperature (in Kelvin)
 // V: volume of the air and water vapor mixture
+//
+// The saturation vapour pressure is calculated with the Arden Buck equation over water.
+// Use HumidityConverter for selecting a different formula.
 func Relative2AbsoluteHumidity(relativeHumidity float64, tempCelsius float64) float64 {
-	tempKelvin := tempCelsius + 273.15
-	return 1000 * relativeHumidity * saturationVaporPressureWater(tempCelsius) / (gasConstantWater * tempKelvin)
+	return HumidityConverter{}.Relative2AbsoluteHumidity(relativeHumidity, tempCelsius)
+}
+
+// Absolute2RelativeHumidity calculates the relative humidity in percent for a given
+// absolute humidity in g/m³ and temperature in Celsius. It is the inverse of
+// Relative2AbsoluteHumidity.
+func Absolute2RelativeHumidity(absoluteHumidity float64, tempCelsius float64) float64 {
+	return HumidityConverter{}.Absolute2RelativeHumidity(absoluteHumidity, tempCelsius)
+}
+
+// CorrectRelativeHumidity recalculates the relative humidity in percent that was
+// measured at the sensor temperature for the corrected temperature (both in Celsius).
+//
+// A sensor that heats itself up measures the relative humidity at its (hotter) die
+// temperature. Correcting only the temperature would lead to physically inconsistent
+// values. The amount of water vapor in the air stays the same though, so the absolute
+// humidity is calculated from the raw values and converted back to relative humidity
+// at the corrected temperature.
+//
+// The result is capped at 100 %. More water vapor than that cannot stay in the air
+// at the corrected temperature, but would condense.
+//
+// The saturation vapour pressure is calculated with the Arden Buck equation over water.
+// Use HumidityConverter for selecting a different formula.
+func CorrectRelativeHumidity(relativeHumidity float64, sensorTempCelsius float64, tempCelsius float64) float64 {
+	return HumidityConverter{}.CorrectRelativeHumidity(relativeHumidity, sensorTempCelsius, tempCelsius)
+}
+
+// DewPoint calculates the dew point in Celsius for a given relative humidity and
+// temperature in Celsius by solving the Arden Buck equation for the temperature at
+// which the saturation vapour pressure equals the partial vapor pressure of water:
+//
+// γ = ln(p_water / 6.1121 hPa) = (18.678 - T_d / 234.5) * (T_d / (257.14 + T_d))
+//
+// Resulting formula: T_d = 234.5 / 2 * (18.678 - γ - sqrt((18.678 - γ)² - 4 * 257.14 * γ / 234.5))
+//
+// Air without water vapor (relative humidity of 0 % or less) has no dew point.
+// Negative infinity is returned in that case. Use HumidityConverter for selecting
+// a different saturation vapour pressure formula.
+func DewPoint(relativeHumidity float64, tempCelsius float64) float64 {
+	if relativeHumidity <= 0 {
+		return math.Inf(-1)
+	}
+	gamma := math.Log(relativeHumidity / 100 * saturationVaporPressureWater(tempCelsius) / 6.1121)
+	b := 18.678 - gamma
+	return 234.5 / 2 * (b - math.Sqrt(b*b-4*257.14*gamma/234.5))
+}
+
+// DewPointDepression calculates the difference in Kelvin between a surface
+// temperature and the dew point of the air (given as relative humidity and air
+// temperature in Celsius). Water condenses on the surface if it is zero or negative.
+// It is positive infinity for air without water vapor (see DewPoint).
+func DewPointDepression(surfaceTempCelsius float64, relativeHumidity float64, airTempCelsius float64) float64 {
+	return surfaceTempCelsius - DewPoint(relativeHumidity, airTempCelsius)
 }
@@ -31,3 +31,196 @@ func TestRelative2AbsoluteHumidity(t *testing.T) {
 		}
 	}
 }
+
+func TestAbsolute2RelativeHumidity(t *testing.T) {
+	tests := []struct {
+		ah          float64
+		tempCelsius float64
+		rh          float64
+	}{
+		{6.9, 20.0, 40.0},
+		{6.4, 15.0, 50.0},
+		{12.1, 20.0, 70.0},
+		{10.3, 15.0, 80.0},
+		{16.6, 50.0, 20.0},
+	}
+
+	for _, test := range tests {
+		rh := Absolute2RelativeHumidity(test.ah, test.tempCelsius)
+		if math.Abs(rh-test.rh) > 0.5 {
+			t.Errorf(
+				"Relative humidity for %f g/m³ at %f° C was incorrect, got: %f, want: %f.",
+				test.ah, test.tempCelsius, rh, test.rh)
+		}
+	}
+}
+
+func TestCorrectRelativeHumidity(t *testing.T) {
+	tests := []struct {
+		rh                float64
+		sensorTempCelsius float64
+		tempCelsius       float64
+		correctedRH       float64
+	}{
+		{50.0, 20.0, 20.0, 50.0},
+		{50.0, 21.5, 20.0, 54.6},
+		{40.0, 25.0, 20.0, 53.3},
+		{95.0, 21.5, 20.0, 100.0},
+		{100.0, 25.0, 20.0, 100.0},
+	}
+
+	for _, test := range tests {
+		rh := CorrectRelativeHumidity(test.rh, test.sensorTempCelsius, test.tempCelsius)
+		if math.Abs(rh-test.correctedRH) > 0.05 {
+			t.Errorf(
+				"Corrected humidity for %f%% humidity at %f° C for %f° C was incorrect, got: %f, want: %f.",
+				test.rh, test.sensorTempCelsius, test.tempCelsius, rh, test.correctedRH)
+		}
+	}
+}
+
+func TestDewPoint(t *testing.T) {
+	tests := []struct {
+		rh          float64
+		tempCelsius float64
+		dewPoint    float64
+	}{
+		{100.0, 20.0, 20.0},
+		{50.0, 20.0, 9.3},
+		{80.0, 15.0, 11.6},
+		{60.0, 25.0, 16.7},
+		{80.0, -10.0, -12.8},
+		{0.0, 20.0, math.Inf(-1)},
+		{-0.5, 20.0, math.Inf(-1)},
+	}
+
+	for _, test := range tests {
+		dewPoint := DewPoint(test.rh, test.tempCelsius)
+		if dewPoint != test.dewPoint && !(math.Abs(dewPoint-test.dewPoint) <= 0.05) {
+			t.Errorf(
+				"Dew point for %f%% humidity at %f° C was incorrect, got: %f, want: %f.",
+				test.rh, test.tempCelsius, dewPoint, test.dewPoint)
+		}
+	}
+}
+
+func TestDewPointDepression(t *testing.T) {
+	tests := []struct {
+		surfaceTempCelsius float64
+		rh                 float64
+		airTempCelsius     float64
+		depression         float64
+	}{
+		{20.0, 50.0, 20.0, 10.7},
+		{12.0, 50.0, 20.0, 2.7},
+		{8.0, 50.0, 20.0, -1.3},
+		{8.0, 0.0, 20.0, math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		depression := DewPointDepression(test.surfaceTempCelsius, test.rh, test.airTempCelsius)
+		if depression != test.depression && !(math.Abs(depression-test.depression) <= 0.05) {
+			t.Errorf(
+				"Dew point depression for surface at %f° C and air with %f%% humidity at %f° C was incorrect, got: %f, want: %f.",
+				test.surfaceTempCelsius, test.rh, test.airTempCelsius, depression, test.depression)
+		}
+	}
+}
+
+func TestHumidityConverter(t *testing.T) {
+	tests := []struct {
+		converter   HumidityConverter
+		rh          float64
+		tempCelsius float64
+		ah          float64
+	}{
+		{HumidityConverter{}, 80.0, -10.0, 1.88},
+		{HumidityConverter{Formula: Magnus}, 80.0, -10.0, 1.88},
+		{HumidityConverter{Formula: WexlerHardy}, 80.0, -10.0, 1.88},
+		{HumidityConverter{OverIce: true}, 80.0, -10.0, 1.71},
+		{HumidityConverter{Formula: Magnus, OverIce: true}, 80.0, -10.0, 1.71},
+		{HumidityConverter{Formula: WexlerHardy, OverIce: true}, 80.0, -10.0, 1.71},
+		{HumidityConverter{Formula: WexlerHardy, OverIce: true}, 40.0, 20.0, 6.92},
+	}
+
+	for _, test := range tests {
+		ah := test.converter.Relative2AbsoluteHumidity(test.rh, test.tempCelsius)
+		if math.Abs(ah-test.ah) > 0.01 {
+			t.Errorf(
+				"Absolute humidity with %v for %f%% humidity at %f° C was incorrect, got: %f, want: %f.",
+				test.converter, test.rh, test.tempCelsius, ah, test.ah)
+		}
+		rh := test.converter.Absolute2RelativeHumidity(ah, test.tempCelsius)
+		if math.Abs(rh-test.rh) > 1e-9 {
+			t.Errorf(
+				"Relative humidity with %v for %f g/m³ at %f° C was incorrect, got: %f, want: %f.",
+				test.converter, ah, test.tempCelsius, rh, test.rh)
+		}
+	}
+}
+
+func TestHumidityConverterCorrectRelativeHumidity(t *testing.T) {
+	for _, formula := range []SaturationVaporPressureFormula{ArdenBuck, Magnus, WexlerHardy} {
+		converter := HumidityConverter{Formula: formula}
+		tests := []struct {
+			rh                float64
+			sensorTempCelsius float64
+			tempCelsius       float64
+			correctedRH       float64
+		}{
+			{50.0, 21.5, 20.0, 54.6},
+			{40.0, 25.0, 20.0, 53.3},
+			{95.0, 21.5, 20.0, 100.0},
+		}
+
+		for _, test := range tests {
+			rh := converter.CorrectRelativeHumidity(test.rh, test.sensorTempCelsius, test.tempCelsius)
+			if math.Abs(rh-test.correctedRH) > 0.05 {
+				t.Errorf(
+					"Corrected humidity with %v for %f%% humidity at %f° C for %f° C was incorrect, got: %f, want: %f.",
+					converter, test.rh, test.sensorTempCelsius, test.tempCelsius, rh, test.correctedRH)
+			}
+		}
+	}
+}
+
+func TestHumidityConverterDewPoint(t *testing.T) {
+	tests := []struct {
+		converter   HumidityConverter
+		rh          float64
+		tempCelsius float64
+		dewPoint    float64
+	}{
+		{HumidityConverter{}, 50.0, 20.0, 9.3},
+		{HumidityConverter{Formula: Magnus}, 50.0, 20.0, 9.3},
+		{HumidityConverter{Formula: WexlerHardy}, 50.0, 20.0, 9.3},
+		{HumidityConverter{Formula: WexlerHardy}, 100.0, 20.0, 20.0},
+		{HumidityConverter{Formula: Magnus}, 110.0, 20.0, 21.5},
+		{HumidityConverter{Formula: WexlerHardy}, 80.0, -10.0, -12.8},
+		{HumidityConverter{Formula: WexlerHardy, OverIce: true}, 80.0, -10.0, -12.5},
+		{HumidityConverter{OverIce: true}, 80.0, -10.0, -12.5},
+		{HumidityConverter{OverIce: true}, 50.0, 10.0, 0.1},
+		{HumidityConverter{Formula: Magnus}, 0.0, 20.0, math.Inf(-1)},
+	}
+
+	for _, rh := range []float64{math.NaN(), math.Inf(1)} {
+		if dewPoint := (HumidityConverter{Formula: Magnus}).DewPoint(rh, 20.0); !math.IsNaN(dewPoint) {
+			t.Errorf("Dew point with magnus for %f%% humidity at 20.000000° C was not NaN, got: %f.", rh, dewPoint)
+		}
+	}
+
+	for _, test := range tests {
+		dewPoint := test.converter.DewPoint(test.rh, test.tempCelsius)
+		if dewPoint != test.dewPoint && !(math.Abs(dewPoint-test.dewPoint) <= 0.05) {
+			t.Errorf(
+				"Dew point with %v for %f%% humidity at %f° C was incorrect, got: %f, want: %f.",
+				test.converter, test.rh, test.tempCelsius, dewPoint, test.dewPoint)
+		}
+		depression := test.converter.DewPointDepression(15.0, test.rh, test.tempCelsius)
+		if depression != 15.0-dewPoint {
+			t.Errorf(
+				"Dew point depression with %v for 15.000000° C was incorrect, got: %f, want: %f.",
+				test.converter, depression, 15.0-dewPoint)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	iaqHumidityBaseline  = 40.0 // optimal indoor relative humidity in percent
+	iaqHumidityWeighting = 0.25 // share of the humidity in the air quality score
+)
+
+// GasAirQualityIndex estimates an indoor air quality index from the gas resistance
+// of a metal oxide sensor (like the BME680 or BME688) and the relative humidity.
+//
+// Bosch's BSEC library is closed source, so this is only a heuristic approximation
+// and not comparable to the BSEC IAQ output. The gas resistance drops with rising
+// concentration of volatile organic compounds. The gas baseline is the resistance
+// in clean air (e.g. the average over the last minutes of a burn-in period) in the
+// same unit as the gas resistance. It has to be positive, so the index cannot be
+// calculated before the burn-in period is over.
+//
+// The humidity contributes 25 % to the air quality score (best at 40 % relative
+// humidity) and the gas resistance compared to the baseline the remaining 75 %.
+// The resulting score (100 % = good) is mapped to the index range from 0 (good)
+// to 500 (hazardous) used by BSEC.
+func GasAirQualityIndex(gasResistance float64, gasBaseline float64, relativeHumidity float64) (float64, error) {
+	if !(gasBaseline > 0) {
+		return 0, fmt.Errorf("gas baseline %g is not positive", gasBaseline)
+	}
+	if !(gasResistance >= 0) {
+		return 0, fmt.Errorf("gas resistance %g is negative", gasResistance)
+	}
+	var humidityScore float64
+	humidityOffset := relativeHumidity - iaqHumidityBaseline
+	if humidityOffset > 0 {
+		humidityScore = (100 - iaqHumidityBaseline - humidityOffset) / (100 - iaqHumidityBaseline)
+	} else {
+		humidityScore = (iaqHumidityBaseline + humidityOffset) / iaqHumidityBaseline
+	}
+	if humidityScore < 0 {
+		humidityScore = 0
+	}
+
+	gasScore := 1.0
+	if gasResistance < gasBaseline {
+		gasScore = gasResistance / gasBaseline
+	}
+
+	score := iaqHumidityWeighting*humidityScore + (1-iaqHumidityWeighting)*gasScore
+	return 500 * (1 - score), nil
+}
+
+// airQualityRange is the range of a reading from good (score 100) to bad (score 0).
+// Readings in between are interpolated linearly. Readings beyond the good value
+// have the score 100.
+type airQualityRange struct {
+	good float64
+	bad  float64
+}
+
+// Ranges of the readings that can be combined to a composite air quality score
+var airQualityRanges = map[string][]airQualityRange{
+	"co2":  {{good: 600, bad: 1500}}, // CO2 concentration in ppm
+	"voc":  {{good: 100, bad: 400}},  // Sensirion VOC index (100 = average of the last 24 hours)
+	"pm25": {{good: 12, bad: 55}},    // PM2.5 mass concentration in µg/m³
+	// relative humidity in percent: optimal between 40 % and 60 %
+	"humidity": {{good: 40, bad: 20}, {good: 60, bad: 80}},
+}
+
+// airQualitySubScore calculates the score from 0 (bad) to 100 (good) for one reading.
+// With multiple ranges the worst score is taken.
+func airQualitySubScore(value float64, ranges []airQualityRange) float64 {
+	score := 100.0
+	for _, r := range ranges {
+		score = math.Min(score, math.Max(0, 100*(r.bad-value)/(r.bad-r.good)))
+	}
+	return score
+}
+
+// CompositeAirQualityScore combines the readings of co-located sensors to one
+// air quality score from 0 (bad) to 100 (good). The readings and weights are keyed
+// by the reading name ("co2", "voc", "pm25", "humidity"). The score is the weighted
+// average of the scores of the single readings. Readings without weight are ignored.
+// Weights must not be negative.
+func CompositeAirQualityScore(readings map[string]float64, weights map[string]float64) (float64, error) {
+	var score, totalWeight float64
+	for name, weight := range weights {
+		ranges, ok := airQualityRanges[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown air quality reading '%s'", name)
+		}
+		if weight < 0 {
+			return 0, fmt.Errorf("negative weight %g for air quality reading '%s'", weight, name)
+		}
+		value, ok := readings[name]
+		if !ok || weight == 0 {
+			continue
+		}
+		score += weight * airQualitySubScore(value, ranges)
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return 0, fmt.Errorf("no weighted readings available for the air quality score")
+	}
+	return score / totalWeight, nil
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGasAirQualityIndex(t *testing.T) {
+	tests := []struct {
+		gasResistance    float64
+		gasBaseline      float64
+		relativeHumidity float64
+		iaq              float64
+	}{
+		{250000.0, 250000.0, 40.0, 0.0},
+		{300000.0, 250000.0, 40.0, 0.0},
+		{125000.0, 250000.0, 40.0, 187.5},
+		{250000.0, 250000.0, 70.0, 62.5},
+		{250000.0, 250000.0, 20.0, 62.5},
+		{0.0, 250000.0, 100.0, 500.0},
+	}
+
+	for _, test := range tests {
+		iaq, err := GasAirQualityIndex(test.gasResistance, test.gasBaseline, test.relativeHumidity)
+		if err != nil {
+			t.Errorf("Calculating IAQ for %f Ω gas resistance (baseline %f Ω) failed: %v",
+				test.gasResistance, test.gasBaseline, err)
+		} else if math.Abs(iaq-test.iaq) > 1e-9 {
+			t.Errorf(
+				"IAQ for %f Ω gas resistance (baseline %f Ω) at %f%% humidity was incorrect, got: %f, want: %f.",
+				test.gasResistance, test.gasBaseline, test.relativeHumidity, iaq, test.iaq)
+		}
+	}
+}
+
+func TestGasAirQualityIndexInvalid(t *testing.T) {
+	tests := []struct {
+		gasResistance float64
+		gasBaseline   float64
+	}{
+		{100.0, 0.0},
+		{100.0, -250000.0},
+		{100.0, math.NaN()},
+		{-100.0, 250000.0},
+		{math.NaN(), 250000.0},
+	}
+
+	for _, test := range tests {
+		if _, err := GasAirQualityIndex(test.gasResistance, test.gasBaseline, 40.0); err == nil {
+			t.Errorf("Calculating IAQ for %f Ω gas resistance (baseline %f Ω) did not fail.",
+				test.gasResistance, test.gasBaseline)
+		}
+	}
+}
+
+func TestCompositeAirQualityScore(t *testing.T) {
+	tests := []struct {
+		readings map[string]float64
+		weights  map[string]float64
+		score    float64
+	}{
+		{map[string]float64{"co2": 500}, map[string]float64{"co2": 1}, 100.0},
+		{map[string]float64{"co2": 1050}, map[string]float64{"co2": 1}, 50.0},
+		{map[string]float64{"co2": 2000}, map[string]float64{"co2": 1}, 0.0},
+		{map[string]float64{"humidity": 50}, map[string]float64{"humidity": 1}, 100.0},
+		{map[string]float64{"humidity": 30}, map[string]float64{"humidity": 1}, 50.0},
+		{map[string]float64{"humidity": 75}, map[string]float64{"humidity": 1}, 25.0},
+		{
+			map[string]float64{"co2": 1050, "voc": 100, "pm25": 55, "humidity": 45},
+			map[string]float64{"co2": 2, "voc": 1, "pm25": 1},
+			50.0,
+		},
+		{
+			map[string]float64{"co2": 1050},
+			map[string]float64{"co2": 2, "voc": 1, "pm25": 1},
+			50.0,
+		},
+	}
+
+	for _, test := range tests {
+		score, err := CompositeAirQualityScore(test.readings, test.weights)
+		if err != nil {
+			t.Errorf("Calculating air quality score for %v failed: %v", test.readings, err)
+			continue
+		}
+		if math.Abs(score-test.score) > 1e-9 {
+			t.Errorf(
+				"Air quality score for %v with weights %v was incorrect, got: %f, want: %f.",
+				test.readings, test.weights, score, test.score)
+		}
+	}
+}
+
+func TestCompositeAirQualityScoreInvalid(t *testing.T) {
+	tests := []struct {
+		readings map[string]float64
+		weights  map[string]float64
+	}{
+		{map[string]float64{"co2": 500}, nil},
+		{map[string]float64{"co2": 500}, map[string]float64{"voc": 1}},
+		{map[string]float64{"co2": 500}, map[string]float64{"co2": 1, "radon": 1}},
+		{map[string]float64{"co2": 500, "voc": 400}, map[string]float64{"co2": 1, "voc": -0.5}},
+		{map[string]float64{"co2": 500}, map[string]float64{"co2": -1}},
+	}
+
+	for _, test := range tests {
+		if _, err := CompositeAirQualityScore(test.readings, test.weights); err == nil {
+			t.Errorf("Calculating air quality score for %v with weights %v did not fail.",
+				test.readings, test.weights)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const speedOfSoundZeroCelsius = 331.3 // speed of sound in dry air at 0° C in m/s
+
+// FillLevel calculates the fill level of a tank in percent from the distance
+// between a sensor mounted on top of the tank and the liquid surface. The empty
+// distance is the distance to the bottom (0 %) and the full distance is the
+// distance to the surface of a full tank (100 %), so the full distance has to be
+// smaller than the empty distance. All distances have to use the same unit. The
+// fill level is limited to the range from 0 % to 100 %.
+func FillLevel(distance float64, emptyDistance float64, fullDistance float64) (float64, error) {
+	if !(fullDistance < emptyDistance) {
+		return 0, fmt.Errorf(
+			"full distance %g has to be smaller than the empty distance %g", fullDistance, emptyDistance)
+	}
+	if math.IsNaN(distance) {
+		return 0, fmt.Errorf("invalid distance %g", distance)
+	}
+	level := 100 * (emptyDistance - distance) / (emptyDistance - fullDistance)
+	if level < 0 {
+		return 0, nil
+	} else if level > 100 {
+		return 100, nil
+	}
+	return level, nil
+}
+
+// SpeedOfSound calculates the speed of sound in dry air in m/s for the given
+// temperature in Celsius.
+func SpeedOfSound(tempCelsius float64) float64 {
+	return speedOfSoundZeroCelsius * math.Sqrt(1+tempCelsius/273.15)
+}
+
+// EchoDistance calculates the distance in meters to an object from the time an
+// ultrasonic pulse needs to travel to the object and back, compensating the speed
+// of sound for the air temperature in Celsius.
+func EchoDistance(echoTime time.Duration, tempCelsius float64) float64 {
+	return SpeedOfSound(tempCelsius) * echoTime.Seconds() / 2
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFillLevel(t *testing.T) {
+	tests := []struct {
+		distance      float64
+		emptyDistance float64
+		fullDistance  float64
+		level         float64
+	}{
+		{1.2, 1.2, 0.2, 0.0},
+		{0.2, 1.2, 0.2, 100.0},
+		{0.7, 1.2, 0.2, 50.0},
+		{0.95, 1.2, 0.2, 25.0},
+		{1.5, 1.2, 0.2, 0.0},
+		{0.1, 1.2, 0.2, 100.0},
+	}
+
+	for _, test := range tests {
+		level, err := FillLevel(test.distance, test.emptyDistance, test.fullDistance)
+		if err != nil {
+			t.Errorf("Calculating fill level for distance %f (empty %f, full %f) failed: %v",
+				test.distance, test.emptyDistance, test.fullDistance, err)
+		} else if math.Abs(level-test.level) > 1e-9 {
+			t.Errorf(
+				"Fill level for distance %f (empty %f, full %f) was incorrect, got: %f, want: %f.",
+				test.distance, test.emptyDistance, test.fullDistance, level, test.level)
+		}
+	}
+}
+
+func TestFillLevelInvalid(t *testing.T) {
+	tests := []struct {
+		distance      float64
+		emptyDistance float64
+		fullDistance  float64
+	}{
+		{1.0, 1.0, 1.0},
+		{0.5, 0.2, 1.2},
+		{0.5, math.NaN(), 0.2},
+		{0.5, 1.2, math.NaN()},
+		{math.NaN(), 1.2, 0.2},
+	}
+
+	for _, test := range tests {
+		if _, err := FillLevel(test.distance, test.emptyDistance, test.fullDistance); err == nil {
+			t.Errorf("Calculating fill level for distance %f (empty %f, full %f) did not fail.",
+				test.distance, test.emptyDistance, test.fullDistance)
+		}
+	}
+}
+
+func TestSpeedOfSound(t *testing.T) {
+	tests := []struct {
+		tempCelsius float64
+		speed       float64
+	}{
+		{0.0, 331.3},
+		{20.0, 343.2},
+		{-20.0, 318.9},
+		{35.0, 351.9},
+	}
+
+	for _, test := range tests {
+		speed := SpeedOfSound(test.tempCelsius)
+		if math.Abs(speed-test.speed) > 0.05 {
+			t.Errorf(
+				"Speed of sound at %f° C was incorrect, got: %f, want: %f.",
+				test.tempCelsius, speed, test.speed)
+		}
+	}
+}
+
+func TestEchoDistance(t *testing.T) {
+	tests := []struct {
+		echoTime    time.Duration
+		tempCelsius float64
+		distance    float64
+	}{
+		{5830 * time.Microsecond, 20.0, 1.0},
+		{6037 * time.Microsecond, 0.0, 1.0},
+		{0, 20.0, 0.0},
+	}
+
+	for _, test := range tests {
+		distance := EchoDistance(test.echoTime, test.tempCelsius)
+		if math.Abs(distance-test.distance) > 0.001 {
+			t.Errorf(
+				"Distance for echo time %v at %f° C was incorrect, got: %f, want: %f.",
+				test.echoTime, test.tempCelsius, distance, test.distance)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	pressureTendencyPeriod    = 3 * time.Hour // period of the pressure tendency
+	pressureTendencyThreshold = 1.6           // pressure changes within 3 hours in hPa that are considered steady
+)
+
+// PressureTendency is the direction of the air pressure change over 3 hours.
+type PressureTendency int
+
+// Pressure tendencies
+const (
+	PressureFalling PressureTendency = -1
+	PressureSteady  PressureTendency = 0
+	PressureRising  PressureTendency = 1
+)
+
+func (t PressureTendency) String() string {
+	switch t {
+	case PressureFalling:
+		return "falling"
+	case PressureRising:
+		return "rising"
+	default:
+		return "steady"
+	}
+}
+
+// ClassifyPressureTendency classifies the air pressure change in hPa over the last
+// 3 hours as falling, steady or rising.
+func ClassifyPressureTendency(pressureChange float64) PressureTendency {
+	if pressureChange <= -pressureTendencyThreshold {
+		return PressureFalling
+	} else if pressureChange >= pressureTendencyThreshold {
+		return PressureRising
+	}
+	return PressureSteady
+}
+
+// ZambrettiCode calculates the Zambretti forecast code for the sea level pressure
+// in hPa and the pressure tendency with the simplified Zambretti algorithm.
+// Falling pressure results in the codes 1 to 9, steady pressure in 10 to 19 and
+// rising pressure in 20 to 32. Lower codes within each group mean better weather.
+func ZambrettiCode(seaLevelPressure float64, tendency PressureTendency) (int, error) {
+	if math.IsNaN(seaLevelPressure) {
+		return 0, fmt.Errorf("invalid sea level pressure %g", seaLevelPressure)
+	}
+	var code float64
+	var minCode, maxCode float64
+	switch tendency {
+	case PressureFalling:
+		code, minCode, maxCode = 127-0.12*seaLevelPressure, 1, 9
+	case PressureRising:
+		code, minCode, maxCode = 185-0.16*seaLevelPressure, 20, 32
+	default:
+		code, minCode, maxCode = 144-0.13*seaLevelPressure, 10, 19
+	}
+	return int(math.Max(minCode, math.Min(maxCode, math.Round(code)))), nil
+}
+
+// PressureHistory keeps the air pressure readings of the last 3 hours for
+// calculating the pressure tendency.
+type PressureHistory struct {
+	pressures  []float64
+	timestamps []time.Time
+}
+
+// Add adds a pressure reading in hPa. Readings that are NaN or not newer than the
+// previous one are ignored. Readings that are not needed any more are dropped.
+func (h *PressureHistory) Add(pressure float64, timestamp time.Time) {
+	if math.IsNaN(pressure) || (len(h.timestamps) > 0 && !timestamp.After(h.timestamps[len(h.timestamps)-1])) {
+		return
+	}
+	h.pressures = append(h.pressures, pressure)
+	h.timestamps = append(h.timestamps, timestamp)
+	// Keep the newest reading that is at least 3 hours old for the interpolation.
+	start := timestamp.Add(-pressureTendencyPeriod)
+	drop := 0
+	for drop+1 < len(h.timestamps) && !h.timestamps[drop+1].After(start) {
+		drop++
+	}
+	h.pressures = h.pressures[drop:]
+	h.timestamps = h.timestamps[drop:]
+}
+
+// Change returns the pressure change in hPa over the last 3 hours. The pressure
+// 3 hours before the newest reading is interpolated linearly between the readings
+// around that time. It fails if the readings do not cover 3 hours yet.
+func (h *PressureHistory) Change() (float64, error) {
+	if len(h.timestamps) < 2 {
+		return 0, fmt.Errorf("not enough pressure readings for the last %v", pressureTendencyPeriod)
+	}
+	newest := len(h.timestamps) - 1
+	start := h.timestamps[newest].Add(-pressureTendencyPeriod)
+	if h.timestamps[0].After(start) {
+		return 0, fmt.Errorf("pressure readings only cover the last %v, but %v are needed",
+			h.timestamps[newest].Sub(h.timestamps[0]), pressureTendencyPeriod)
+	}
+	// The first reading is at or before the start and the second one after it.
+	ratio := float64(start.Sub(h.timestamps[0])) / float64(h.timestamps[1].Sub(h.timestamps[0]))
+	startPressure := h.pressures[0] + ratio*(h.pressures[1]-h.pressures[0])
+	return h.pressures[newest] - startPressure, nil
+}
+
+// Tendency classifies the pressure change over the last 3 hours.
+func (h *PressureHistory) Tendency() (PressureTendency, error) {
+	change, err := h.Change()
+	if err != nil {
+		return PressureSteady, err
+	}
+	return ClassifyPressureTendency(change), nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestClassifyPressureTendency(t *testing.T) {
+	tests := []struct {
+		pressureChange float64
+		tendency       PressureTendency
+	}{
+		{0.0, PressureSteady},
+		{1.5, PressureSteady},
+		{-1.5, PressureSteady},
+		{1.6, PressureRising},
+		{4.2, PressureRising},
+		{-1.6, PressureFalling},
+		{-6.0, PressureFalling},
+	}
+
+	for _, test := range tests {
+		tendency := ClassifyPressureTendency(test.pressureChange)
+		if tendency != test.tendency {
+			t.Errorf(
+				"Pressure tendency for a change of %f hPa was incorrect, got: %v, want: %v.",
+				test.pressureChange, tendency, test.tendency)
+		}
+	}
+}
+
+func TestZambrettiCode(t *testing.T) {
+	tests := []struct {
+		pressure float64
+		tendency PressureTendency
+		code     int
+	}{
+		{1000.0, PressureFalling, 7},
+		{1040.0, PressureFalling, 2},
+		{950.0, PressureFalling, 9},
+		{1013.0, PressureSteady, 12},
+		{1050.0, PressureSteady, 10},
+		{1020.0, PressureRising, 22},
+		{1060.0, PressureRising, 20},
+		{940.0, PressureRising, 32},
+	}
+
+	for _, test := range tests {
+		code, err := ZambrettiCode(test.pressure, test.tendency)
+		if err != nil {
+			t.Errorf("Calculating Zambretti code for %f hPa (%v) failed: %v", test.pressure, test.tendency, err)
+		} else if code != test.code {
+			t.Errorf(
+				"Zambretti code for %f hPa (%v) was incorrect, got: %d, want: %d.",
+				test.pressure, test.tendency, code, test.code)
+		}
+	}
+}
+
+func TestZambrettiCodeNaN(t *testing.T) {
+	if code, err := ZambrettiCode(math.NaN(), PressureSteady); err == nil {
+		t.Errorf("Calculating Zambretti code for NaN did not fail, got: %d.", code)
+	}
+}
+
+func TestPressureHistory(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		offset   time.Duration
+		pressure float64
+		change   float64
+		tendency PressureTendency
+		valid    bool
+	}{
+		{0, 1010.0, 0.0, PressureSteady, false},
+		{time.Hour, 1011.0, 0.0, PressureSteady, false},
+		{time.Hour, 1020.0, 0.0, PressureSteady, false},
+		{2 * time.Hour, math.NaN(), 0.0, PressureSteady, false},
+		{3 * time.Hour, 1012.0, 2.0, PressureRising, true},
+		{210 * time.Minute, 1011.0, 0.5, PressureSteady, true},
+		{5 * time.Hour, 1009.0, -2.5, PressureFalling, true},
+		{9 * time.Hour, 1009.0, 0.0, PressureSteady, true},
+	}
+
+	var history PressureHistory
+	for _, test := range tests {
+		history.Add(test.pressure, start.Add(test.offset))
+		change, err := history.Change()
+		if !test.valid {
+			if err == nil {
+				t.Errorf("Pressure change after %v did not fail, got: %f.", test.offset, change)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Calculating pressure change after %v failed: %v", test.offset, err)
+			continue
+		}
+		if math.Abs(change-test.change) > 1e-9 {
+			t.Errorf("Pressure change after %v was incorrect, got: %f, want: %f.",
+				test.offset, change, test.change)
+		}
+		if tendency, _ := history.Tendency(); tendency != test.tendency {
+			t.Errorf("Pressure tendency after %v was incorrect, got: %v, want: %v.",
+				test.offset, tendency, test.tendency)
+		}
+	}
+}
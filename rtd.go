@@ -0,0 +1,63 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Callendar–Van Dusen coefficients for platinum resistance thermometers (IEC 60751)
+const (
+	rtdA = 3.9083e-3
+	rtdB = -5.775e-7
+	rtdC = -4.183e-12
+)
+
+// rtdResistanceRatio calculates R(T) / R0 with the Callendar–Van Dusen equation:
+//
+// R(T) = R0 * (1 + A * T + B * T² + C * (T - 100) * T³)
+//
+// The term with C is only used below 0° C.
+func rtdResistanceRatio(tempCelsius float64) float64 {
+	ratio := 1 + rtdA*tempCelsius + rtdB*tempCelsius*tempCelsius
+	if tempCelsius < 0 {
+		ratio += rtdC * (tempCelsius - 100) * tempCelsius * tempCelsius * tempCelsius
+	}
+	return ratio
+}
+
+// RTDTemperature calculates the temperature in Celsius of a platinum resistance
+// thermometer from its resistance and its nominal resistance at 0° C (100 Ω for
+// PT100, 1000 Ω for PT1000) by inverting the Callendar–Van Dusen equation. The
+// quadratic equation is solved directly above 0° C, below 0° C the result is
+// refined with Newton's method.
+func RTDTemperature(resistance float64, nominalResistance float64) (float64, error) {
+	if !(nominalResistance > 0) {
+		return 0, fmt.Errorf("nominal resistance %g is not positive", nominalResistance)
+	}
+	if !(resistance > 0) || math.IsInf(resistance, 0) {
+		return 0, fmt.Errorf("invalid RTD resistance %g", resistance)
+	}
+	ratio := resistance / nominalResistance
+	temp := (-rtdA + math.Sqrt(rtdA*rtdA-4*rtdB*(1-ratio))) / (2 * rtdB)
+	if ratio < 1 {
+		for i := 0; i < 10; i++ {
+			slope := rtdA + 2*rtdB*temp + rtdC*(4*temp-300)*temp*temp
+			temp -= (rtdResistanceRatio(temp) - ratio) / slope
+		}
+	}
+	return temp, nil
+}
+
+// MAX31865Resistance calculates the RTD resistance from the RTD data registers
+// (MSB and LSB combined) of a MAX31865 and its reference resistor (e.g. 430 Ω
+// for PT100 or 4300 Ω for PT1000). The lowest bit of the registers is the fault
+// bit. If it is set, the fault status register has to be read for the cause.
+func MAX31865Resistance(rtdRegisters uint16, referenceResistance float64) (float64, error) {
+	if rtdRegisters&1 != 0 {
+		return 0, fmt.Errorf("MAX31865 reports a fault (RTD data registers 0x%04x)", rtdRegisters)
+	}
+	return float64(rtdRegisters>>1) * referenceResistance / 32768, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRTDTemperature(t *testing.T) {
+	tests := []struct {
+		resistance        float64
+		nominalResistance float64
+		tempCelsius       float64
+	}{
+		{100.0, 100.0, 0.0},
+		{138.5055, 100.0, 100.0},
+		{175.856, 100.0, 200.0},
+		{60.2558, 100.0, -100.0},
+		{18.5201, 100.0, -200.0},
+		{1097.3466, 1000.0, 25.0},
+		{960.8588, 1000.0, -10.0},
+	}
+
+	for _, test := range tests {
+		temp, err := RTDTemperature(test.resistance, test.nominalResistance)
+		if err != nil {
+			t.Errorf("Calculating temperature for %f Ω (R0 %f Ω) failed: %v",
+				test.resistance, test.nominalResistance, err)
+		} else if math.Abs(temp-test.tempCelsius) > 0.001 {
+			t.Errorf("Temperature for %f Ω (R0 %f Ω) was incorrect, got: %f, want: %f.",
+				test.resistance, test.nominalResistance, temp, test.tempCelsius)
+		}
+	}
+}
+
+func TestRTDTemperatureInvalid(t *testing.T) {
+	tests := []struct {
+		resistance        float64
+		nominalResistance float64
+	}{
+		{100.0, 0.0},
+		{100.0, -100.0},
+		{100.0, math.NaN()},
+		{0.0, 100.0},
+		{-10.0, 100.0},
+		{math.NaN(), 100.0},
+		{math.Inf(1), 100.0},
+	}
+
+	for _, test := range tests {
+		if _, err := RTDTemperature(test.resistance, test.nominalResistance); err == nil {
+			t.Errorf("Calculating temperature for %f Ω (R0 %f Ω) did not fail.",
+				test.resistance, test.nominalResistance)
+		}
+	}
+}
+
+func TestMAX31865Resistance(t *testing.T) {
+	tests := []struct {
+		rtdRegisters        uint16
+		referenceResistance float64
+		resistance          float64
+	}{
+		{0x0000, 430.0, 0.0},
+		{0x3b88, 430.0, 99.993896484375},
+		{0xfffe, 4300.0, 4299.8687744140625},
+	}
+
+	for _, test := range tests {
+		resistance, err := MAX31865Resistance(test.rtdRegisters, test.referenceResistance)
+		if err != nil {
+			t.Errorf("Calculating resistance for registers 0x%04x failed: %v", test.rtdRegisters, err)
+		} else if math.Abs(resistance-test.resistance) > 1e-9 {
+			t.Errorf("Resistance for registers 0x%04x (reference %f Ω) was incorrect, got: %f, want: %f.",
+				test.rtdRegisters, test.referenceResistance, resistance, test.resistance)
+		}
+	}
+
+	if _, err := MAX31865Resistance(0x3b89, 430.0); err == nil {
+		t.Errorf("Calculating resistance for registers 0x3b89 with fault bit did not fail.")
+	}
+}
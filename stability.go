@@ -0,0 +1,82 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// StabilityReport summarizes the readings of one metric of a sensor that was
+// polled continuously for qualifying it (burn-in / soak test).
+type StabilityReport struct {
+	Samples int     // number of successful readings
+	Errors  int     // number of failed readings
+	Mean    float64 // average of the readings
+	StdDev  float64 // standard deviation of the readings (noise)
+	Drift   float64 // change of the readings per hour (least squares slope)
+}
+
+// NewStabilityReport calculates the stability report from the successful readings
+// and their timestamps and the number of failed readings. At least two readings
+// with different timestamps are needed to calculate the drift.
+func NewStabilityReport(timestamps []time.Time, values []float64, errors int) (StabilityReport, error) {
+	if len(timestamps) != len(values) {
+		return StabilityReport{}, fmt.Errorf(
+			"got %d timestamps, but %d values", len(timestamps), len(values))
+	}
+	if errors < 0 {
+		return StabilityReport{}, fmt.Errorf("negative number of failed readings %d", errors)
+	}
+	if len(values) < 2 {
+		return StabilityReport{}, fmt.Errorf(
+			"expected at least two readings, but got %d", len(values))
+	}
+
+	report := StabilityReport{Samples: len(values), Errors: errors}
+	for _, value := range values {
+		report.Mean += value / float64(len(values))
+	}
+	for _, value := range values {
+		report.StdDev += (value - report.Mean) * (value - report.Mean)
+	}
+	report.StdDev = math.Sqrt(report.StdDev / float64(len(values)-1))
+
+	var err error
+	report.Drift, err = driftPerHour(timestamps, values, report.Mean)
+	if err != nil {
+		return StabilityReport{}, err
+	}
+	return report, nil
+}
+
+// driftPerHour calculates the slope of the values over time in hours using the
+// least squares method.
+func driftPerHour(timestamps []time.Time, values []float64, meanValue float64) (float64, error) {
+	hours := make([]float64, len(timestamps))
+	var meanHours float64
+	for i, timestamp := range timestamps {
+		hours[i] = timestamp.Sub(timestamps[0]).Hours()
+		meanHours += hours[i] / float64(len(timestamps))
+	}
+	var covariance, variance float64
+	for i := range hours {
+		covariance += (hours[i] - meanHours) * (values[i] - meanValue)
+		variance += (hours[i] - meanHours) * (hours[i] - meanHours)
+	}
+	if variance == 0 {
+		return 0, fmt.Errorf(
+			"cannot calculate drift, because all readings have the timestamp %v", timestamps[0])
+	}
+	return covariance / variance, nil
+}
+
+// ErrorRate returns the share of failed readings from all readings.
+func (r StabilityReport) ErrorRate() float64 {
+	if r.Samples+r.Errors == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Samples+r.Errors)
+}
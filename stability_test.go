@@ -0,0 +1,67 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewStabilityReport(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	timestamps := []time.Time{
+		start, start.Add(30 * time.Minute), start.Add(time.Hour), start.Add(90 * time.Minute),
+	}
+	tests := []struct {
+		values []float64
+		errors int
+		mean   float64
+		stdDev float64
+		drift  float64
+		rate   float64
+	}{
+		{[]float64{21.0, 21.0, 21.0, 21.0}, 0, 21.0, 0.0, 0.0, 0.0},
+		{[]float64{21.0, 21.5, 22.0, 22.5}, 1, 21.75, 0.645497, 1.0, 0.2},
+		{[]float64{21.1, 20.9, 21.1, 20.9}, 4, 21.0, 0.115470, -0.08, 0.5},
+	}
+
+	for _, test := range tests {
+		report, err := NewStabilityReport(timestamps, test.values, test.errors)
+		if err != nil {
+			t.Errorf("Calculating stability report for %v failed: %v", test.values, err)
+			continue
+		}
+		if report.Samples != len(test.values) || report.Errors != test.errors ||
+			math.Abs(report.Mean-test.mean) > 1e-6 || math.Abs(report.StdDev-test.stdDev) > 1e-6 ||
+			math.Abs(report.Drift-test.drift) > 1e-6 || math.Abs(report.ErrorRate()-test.rate) > 1e-6 {
+			t.Errorf(
+				"Stability report for %v was incorrect, got: %+v (error rate %f), want: mean %f, "+
+					"standard deviation %f, drift %f, error rate %f.",
+				test.values, report, report.ErrorRate(), test.mean, test.stdDev, test.drift, test.rate)
+		}
+	}
+}
+
+func TestNewStabilityReportInvalid(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		timestamps []time.Time
+		values     []float64
+		errors     int
+	}{
+		{nil, nil, 0},
+		{[]time.Time{start}, []float64{21.0}, 0},
+		{[]time.Time{start, start.Add(time.Hour)}, []float64{21.0}, 0},
+		{[]time.Time{start, start}, []float64{21.0, 21.5}, 0},
+		{[]time.Time{start, start.Add(time.Hour)}, []float64{21.0, 21.5}, -3},
+	}
+
+	for _, test := range tests {
+		if _, err := NewStabilityReport(test.timestamps, test.values, test.errors); err == nil {
+			t.Errorf("Calculating stability report for %v at %v with %d errors did not fail.",
+				test.values, test.timestamps, test.errors)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// ApparentTemperature calculates the apparent temperature in Celsius with the
+// formula of Steadman (1994) used by the Australian Bureau of Meteorology for a
+// given temperature in Celsius, relative humidity in percent and wind speed in m/s
+// (use 0 without wind sensor):
+//
+// AT = T + 0.33 * e - 0.70 * ws - 4.00
+//
+// Symbols:
+// AT: apparent temperature (in Celsius)
+// e: water vapour pressure (in hPa)
+// T: temperature (in Celsius)
+// ws: wind speed (in m/s) at an elevation of 10 meters
+//
+// The water vapour pressure is always calculated with the Arden Buck equation over
+// water, because the coefficients of the approximation were fitted empirically and
+// the difference between the formulas is far below its accuracy.
+//
+// See http://www.bom.gov.au/info/thermal_stress/#atapproximation
+func ApparentTemperature(tempCelsius float64, relativeHumidity float64, windSpeed float64) float64 {
+	vaporPressure := relativeHumidity / 100 * saturationVaporPressureWater(tempCelsius)
+	return tempCelsius + 0.33*vaporPressure - 0.70*windSpeed - 4.00
+}
+
+// DegreeDays accumulates heating and cooling degree days relative to a base
+// temperature from a series of temperature readings. Both values only increase
+// and can be exported as counters.
+type DegreeDays struct {
+	BaseCelsius float64
+	Heating     float64
+	Cooling     float64
+
+	lastTempCelsius float64
+	lastTime        time.Time
+}
+
+// NewDegreeDays creates an empty degree day accumulator for the base temperature in Celsius.
+func NewDegreeDays(baseCelsius float64) *DegreeDays {
+	return &DegreeDays{BaseCelsius: baseCelsius}
+}
+
+// Add accumulates the degree days since the previous reading using the average of
+// the previous and the given temperature. The first reading only starts the
+// accumulation. Readings that are not newer than the previous one are ignored.
+func (d *DegreeDays) Add(tempCelsius float64, timestamp time.Time) {
+	if !d.lastTime.IsZero() {
+		if !timestamp.After(d.lastTime) {
+			return
+		}
+		days := timestamp.Sub(d.lastTime).Hours() / 24
+		average := (d.lastTempCelsius + tempCelsius) / 2
+		d.Heating += math.Max(0, d.BaseCelsius-average) * days
+		d.Cooling += math.Max(0, average-d.BaseCelsius) * days
+	}
+	d.lastTempCelsius = tempCelsius
+	d.lastTime = timestamp
+}
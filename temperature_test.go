@@ -0,0 +1,61 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestApparentTemperature(t *testing.T) {
+	tests := []struct {
+		tempCelsius float64
+		rh          float64
+		windSpeed   float64
+		at          float64
+	}{
+		{30.0, 50.0, 0.0, 33.0},
+		{20.0, 50.0, 2.0, 18.5},
+		{10.0, 80.0, 5.0, 5.7},
+		{35.0, 70.0, 1.0, 43.3},
+	}
+
+	for _, test := range tests {
+		at := ApparentTemperature(test.tempCelsius, test.rh, test.windSpeed)
+		if math.Abs(at-test.at) > 0.05 {
+			t.Errorf(
+				"Apparent temperature for %f° C at %f%% humidity and %f m/s wind was incorrect, got: %f, want: %f.",
+				test.tempCelsius, test.rh, test.windSpeed, at, test.at)
+		}
+	}
+}
+
+func TestDegreeDays(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		offset  time.Duration
+		temp    float64
+		heating float64
+		cooling float64
+	}{
+		{0, 10.0, 0.0, 0.0},
+		{24 * time.Hour, 10.0, 5.0, 0.0},
+		{36 * time.Hour, 20.0, 5.0, 0.0},
+		{48 * time.Hour, 24.0, 5.0, 3.5},
+		{48 * time.Hour, 0.0, 5.0, 3.5},
+		{40 * time.Hour, 0.0, 5.0, 3.5},
+		{54 * time.Hour, 18.0, 5.0, 5.0},
+	}
+
+	degreeDays := NewDegreeDays(15.0)
+	for _, test := range tests {
+		degreeDays.Add(test.temp, start.Add(test.offset))
+		if math.Abs(degreeDays.Heating-test.heating) > 1e-9 || math.Abs(degreeDays.Cooling-test.cooling) > 1e-9 {
+			t.Errorf(
+				"Degree days after %f° C at %v were incorrect, got: heating %f, cooling %f, want: heating %f, cooling %f.",
+				test.temp, test.offset, degreeDays.Heating, degreeDays.Cooling, test.heating, test.cooling)
+		}
+	}
+}
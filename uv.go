@@ -0,0 +1,36 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+// VEML6075Coefficients are the coefficients to compensate the UVA and UVB
+// readings of a VEML6075 for visible and infrared light and their
+// responsivities (UV index per count).
+type VEML6075Coefficients struct {
+	A, B, C, D      float64
+	UVAResponsivity float64
+	UVBResponsivity float64
+}
+
+// DefaultVEML6075Coefficients are the coefficients from Vishay's application
+// note "Designing the VEML6075 into an Application" for an open air sensor
+// without diffusor and 100 ms integration time. The responsivities scale
+// inversely with the integration time.
+var DefaultVEML6075Coefficients = VEML6075Coefficients{
+	A: 2.22, B: 1.33, C: 2.95, D: 1.74,
+	UVAResponsivity: 0.001461,
+	UVBResponsivity: 0.002591,
+}
+
+// UVIndex calculates the UV index from the raw UVA, UVB, UVCOMP1 and UVCOMP2
+// readings of a VEML6075 as average of the compensated UVA and UVB index.
+// Negative results (caused by noise in the dark) are returned as 0.
+func (c VEML6075Coefficients) UVIndex(uva float64, uvb float64, uvComp1 float64, uvComp2 float64) float64 {
+	uvaIndex := (uva - c.A*uvComp1 - c.B*uvComp2) * c.UVAResponsivity
+	uvbIndex := (uvb - c.C*uvComp1 - c.D*uvComp2) * c.UVBResponsivity
+	index := (uvaIndex + uvbIndex) / 2
+	if index < 0 {
+		return 0
+	}
+	return index
+}
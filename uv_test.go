@@ -0,0 +1,32 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVEML6075UVIndex(t *testing.T) {
+	tests := []struct {
+		uva     float64
+		uvb     float64
+		uvComp1 float64
+		uvComp2 float64
+		index   float64
+	}{
+		{0.0, 0.0, 0.0, 0.0, 0.0},
+		{1000.0, 500.0, 100.0, 50.0, 0.67261975},
+		{6845.0, 3860.0, 0.0, 0.0, 10.0009},
+		{10.0, 5.0, 100.0, 50.0, 0.0},
+	}
+
+	for _, test := range tests {
+		index := DefaultVEML6075Coefficients.UVIndex(test.uva, test.uvb, test.uvComp1, test.uvComp2)
+		if math.Abs(index-test.index) > 1e-4 {
+			t.Errorf("UV index for UVA %f, UVB %f, UVCOMP1 %f, UVCOMP2 %f was incorrect, got: %f, want: %f.",
+				test.uva, test.uvb, test.uvComp1, test.uvComp2, index, test.index)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// SaturationVaporPressureFormula selects the formula for calculating the
+// saturation vapour pressure. The zero value is the Arden Buck equation.
+type SaturationVaporPressureFormula int
+
+// Supported saturation vapour pressure formulas
+const (
+	ArdenBuck SaturationVaporPressureFormula = iota
+	Magnus
+	WexlerHardy
+)
+
+var saturationVaporPressureFormulaNames = map[SaturationVaporPressureFormula]string{
+	ArdenBuck:   "arden-buck",
+	Magnus:      "magnus",
+	WexlerHardy: "wexler-hardy",
+}
+
+// ParseSaturationVaporPressureFormula returns the formula for the given name
+// ("arden-buck", "magnus", or "wexler-hardy").
+func ParseSaturationVaporPressureFormula(name string) (SaturationVaporPressureFormula, error) {
+	for formula, formulaName := range saturationVaporPressureFormulaNames {
+		if name == formulaName {
+			return formula, nil
+		}
+	}
+	return ArdenBuck, fmt.Errorf("unknown saturation vapor pressure formula '%s'", name)
+}
+
+func (f SaturationVaporPressureFormula) String() string {
+	return saturationVaporPressureFormulaNames[f]
+}
+
+// Water calculates the saturation vapour pressure over water in hPa.
+func (f SaturationVaporPressureFormula) Water(tempCelsius float64) float64 {
+	switch f {
+	case Magnus:
+		return saturationVaporPressureWaterMagnus(tempCelsius)
+	case WexlerHardy:
+		return saturationVaporPressureWaterHardy(tempCelsius)
+	default:
+		return saturationVaporPressureWater(tempCelsius)
+	}
+}
+
+// Ice calculates the saturation vapour pressure over ice in hPa.
+func (f SaturationVaporPressureFormula) Ice(tempCelsius float64) float64 {
+	switch f {
+	case Magnus:
+		return saturationVaporPressureIceMagnus(tempCelsius)
+	case WexlerHardy:
+		return saturationVaporPressureIceHardy(tempCelsius)
+	default:
+		return saturationVaporPressureIce(tempCelsius)
+	}
+}
+
+// saturationVaporPressureIce calculates the saturation vapour pressure over ice in
+// hectopascal (hPa) with the Arden Buck equation.
+func saturationVaporPressureIce(tempCelsius float64) float64 {
+	return 6.1115 * math.Exp((23.036-tempCelsius/333.7)*(tempCelsius/(279.82+tempCelsius)))
+}
+
+// saturationVaporPressureWaterMagnus calculates the saturation vapour pressure over
+// water in hPa with the Magnus formula using the coefficients from Alduchov and
+// Eskridge (1996).
+func saturationVaporPressureWaterMagnus(tempCelsius float64) float64 {
+	return 6.1094 * math.Exp(17.625*tempCelsius/(243.04+tempCelsius))
+}
+
+// saturationVaporPressureIceMagnus calculates the saturation vapour pressure over
+// ice in hPa with the Magnus formula using the coefficients from Alduchov and
+// Eskridge (1996).
+func saturationVaporPressureIceMagnus(tempCelsius float64) float64 {
+	return 6.1121 * math.Exp(22.587*tempCelsius/(273.86+tempCelsius))
+}
+
+// saturationVaporPressureWaterHardy calculates the saturation vapour pressure over
+// water in hPa with the Wexler formula in the ITS-90 version from Hardy (1998).
+func saturationVaporPressureWaterHardy(tempCelsius float64) float64 {
+	g := [...]float64{
+		-2.8365744e3, -6.028076559e3, 1.954263612e1, -2.737830188e-2,
+		1.6261698e-5, 7.0229056e-10, -1.8680009e-13,
+	}
+	tempKelvin := tempCelsius + 273.15
+	lnPressure := 2.7150305 * math.Log(tempKelvin)
+	for i, coefficient := range g {
+		lnPressure += coefficient * math.Pow(tempKelvin, float64(i-2))
+	}
+	return math.Exp(lnPressure) / 100
+}
+
+// saturationVaporPressureIceHardy calculates the saturation vapour pressure over
+// ice in hPa with the Wexler formula in the ITS-90 version from Hardy (1998).
+func saturationVaporPressureIceHardy(tempCelsius float64) float64 {
+	k := [...]float64{-5.8666426e3, 2.232870244e1, 1.39387003e-2, -3.4262402e-5, 2.7040955e-8}
+	tempKelvin := tempCelsius + 273.15
+	lnPressure := 6.7063522e-1 * math.Log(tempKelvin)
+	for i, coefficient := range k {
+		lnPressure += coefficient * math.Pow(tempKelvin, float64(i-1))
+	}
+	return math.Exp(lnPressure) / 100
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSaturationVaporPressureWater(t *testing.T) {
+	tests := []struct {
+		tempCelsius float64
+		pressure    float64
+	}{
+		{-10.0, 2.8627},
+		{0.0, 6.1121},
+		{20.0, 23.389},
+		{50.0, 123.5},
+	}
+
+	for _, formula := range []SaturationVaporPressureFormula{ArdenBuck, Magnus, WexlerHardy} {
+		for _, test := range tests {
+			pressure := formula.Water(test.tempCelsius)
+			if math.Abs(pressure-test.pressure) > 0.005*test.pressure {
+				t.Errorf(
+					"Saturation vapor pressure over water with %v at %f° C was incorrect, got: %f, want: %f.",
+					formula, test.tempCelsius, pressure, test.pressure)
+			}
+		}
+	}
+}
+
+func TestSaturationVaporPressureIce(t *testing.T) {
+	tests := []struct {
+		tempCelsius float64
+		pressure    float64
+	}{
+		{-40.0, 0.1284},
+		{-10.0, 2.5990},
+		{0.0, 6.1115},
+	}
+
+	for _, formula := range []SaturationVaporPressureFormula{ArdenBuck, Magnus, WexlerHardy} {
+		for _, test := range tests {
+			pressure := formula.Ice(test.tempCelsius)
+			if math.Abs(pressure-test.pressure) > 0.005*test.pressure {
+				t.Errorf(
+					"Saturation vapor pressure over ice with %v at %f° C was incorrect, got: %f, want: %f.",
+					formula, test.tempCelsius, pressure, test.pressure)
+			}
+		}
+	}
+}
+
+func TestParseSaturationVaporPressureFormula(t *testing.T) {
+	for _, formula := range []SaturationVaporPressureFormula{ArdenBuck, Magnus, WexlerHardy} {
+		parsed, err := ParseSaturationVaporPressureFormula(formula.String())
+		if err != nil {
+			t.Errorf("Parsing formula '%v' failed: %v", formula, err)
+		} else if parsed != formula {
+			t.Errorf("Parsing formula '%v' returned '%v'.", formula, parsed)
+		}
+	}
+
+	if _, err := ParseSaturationVaporPressureFormula("goff-gratch"); err == nil {
+		t.Errorf("Parsing unknown formula 'goff-gratch' did not fail.")
+	}
+}
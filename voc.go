@@ -0,0 +1,252 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// Based on the Sensirion gas index algorithm, Copyright (c) 2022, Sensirion AG
+// SPDX-License-Identifier: ISC AND BSD-3-Clause
+
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// Parameters of the Sensirion gas index algorithm for VOC
+const (
+	vocInitialBlackout               = 45.0 // seconds without index output after start
+	vocIndexGain                     = 230.0
+	vocSrawStdInitial                = 50.0
+	vocSrawStdBonus                  = 220.0
+	vocTauMeanHours                  = 12.0
+	vocTauVarianceHours              = 12.0
+	vocTauInitialMean                = 20.0
+	vocInitDurationMean              = 3600.0 * 0.75
+	vocInitTransitionMean            = 0.01
+	vocTauInitialVariance            = 2500.0
+	vocInitDurationVariance          = 3600.0 * 1.45
+	vocInitTransitionVariance        = 0.01
+	vocGatingThreshold               = 340.0
+	vocGatingThresholdInitial        = 510.0
+	vocGatingThresholdTransition     = 0.09
+	vocGatingMaxDurationMinutes      = 60.0 * 3.0
+	vocGatingMaxRatio                = 0.3
+	vocSigmoidL                      = 500.0
+	vocSigmoidK                      = -0.0065
+	vocSigmoidX0                     = 213.0
+	vocIndexOffset                   = 100.0
+	vocLowpassTauFast                = 20.0
+	vocLowpassTauSlow                = 500.0
+	vocLowpassAlpha                  = -0.2
+	vocSrawMinimum                   = 20000
+	vocGammaScaling                  = 64.0
+	vocAdditionalGammaMeanScaling    = 8.0
+	vocMeanVarianceEstimatorFix16Max = 32767.0
+)
+
+// VOCIndexAlgorithm calculates the VOC index from the raw signal (SRAW ticks)
+// of a Sensirion SGP40 or SGP41 sensor. It is a port of the gas index algorithm
+// published by Sensirion (https://github.com/Sensirion/gas-index-algorithm).
+//
+// The VOC index ranges from 1 to 500. 100 is the average of the last 24 hours,
+// higher values mean more volatile organic compounds. The index is 0 during the
+// first 45 seconds after the start. The raw signal has to be passed in the
+// sampling interval (usually 1 s), because the filters are tuned for it.
+type VOCIndexAlgorithm struct {
+	samplingInterval float64 // in seconds
+	uptime           float64
+	sraw             float64
+	gasIndex         float64
+
+	estimatorInitialized          bool
+	estimatorMean                 float64
+	estimatorSrawOffset           float64
+	estimatorStd                  float64
+	estimatorGammaMean            float64
+	estimatorGammaVariance        float64
+	estimatorGammaInitialMean     float64
+	estimatorGammaInitialVariance float64
+	estimatorCurrentGammaMean     float64
+	estimatorCurrentGammaVariance float64
+	estimatorUptimeGamma          float64
+	estimatorUptimeGating         float64
+	estimatorGatingMinutes        float64
+	estimatorSigmoidK             float64
+	estimatorSigmoidX0            float64
+
+	moxSrawStd  float64
+	moxSrawMean float64
+
+	lowpassA1          float64
+	lowpassA2          float64
+	lowpassInitialized bool
+	lowpassX1          float64
+	lowpassX2          float64
+	lowpassX3          float64
+}
+
+// NewVOCIndexAlgorithm creates a VOC index algorithm for raw signals that are
+// measured in the given sampling interval.
+func NewVOCIndexAlgorithm(samplingInterval time.Duration) *VOCIndexAlgorithm {
+	a := &VOCIndexAlgorithm{samplingInterval: samplingInterval.Seconds()}
+	a.initEstimator()
+	a.moxSrawStd = a.estimatorStd
+	a.moxSrawMean = a.estimatorMean + a.estimatorSrawOffset
+	a.lowpassA1 = a.samplingInterval / (vocLowpassTauFast + a.samplingInterval)
+	a.lowpassA2 = a.samplingInterval / (vocLowpassTauSlow + a.samplingInterval)
+	return a
+}
+
+// Process feeds the next raw signal of the sensor into the algorithm and returns
+// the VOC index. Raw signals outside of the valid range (1 to 64999) are ignored,
+// but still advance the algorithm.
+func (a *VOCIndexAlgorithm) Process(sraw int) int {
+	if a.uptime <= vocInitialBlackout {
+		a.uptime += a.samplingInterval
+	} else {
+		if sraw > 0 && sraw < 65000 {
+			if sraw < vocSrawMinimum+1 {
+				sraw = vocSrawMinimum + 1
+			} else if sraw > vocSrawMinimum+32767 {
+				sraw = vocSrawMinimum + 32767
+			}
+			a.sraw = float64(sraw - vocSrawMinimum)
+		}
+		a.gasIndex = a.sigmoidScaled(a.moxModel(a.sraw))
+		a.gasIndex = a.adaptiveLowpass(a.gasIndex)
+		if a.gasIndex < 0.5 {
+			a.gasIndex = 0.5
+		}
+		if a.sraw > 0 {
+			a.processEstimator(a.sraw)
+			a.moxSrawStd = a.estimatorStd
+			a.moxSrawMean = a.estimatorMean + a.estimatorSrawOffset
+		}
+	}
+	return int(a.gasIndex + 0.5)
+}
+
+func (a *VOCIndexAlgorithm) initEstimator() {
+	hours := a.samplingInterval / 3600
+	a.estimatorStd = vocSrawStdInitial
+	a.estimatorGammaMean = vocAdditionalGammaMeanScaling * vocGammaScaling * hours / (vocTauMeanHours + hours)
+	a.estimatorGammaVariance = vocGammaScaling * hours / (vocTauVarianceHours + hours)
+	a.estimatorGammaInitialMean = vocAdditionalGammaMeanScaling * vocGammaScaling * a.samplingInterval /
+		(vocTauInitialMean + a.samplingInterval)
+	a.estimatorGammaInitialVariance = vocGammaScaling * a.samplingInterval /
+		(vocTauInitialVariance + a.samplingInterval)
+}
+
+func (a *VOCIndexAlgorithm) estimatorSigmoid(sample float64) float64 {
+	x := a.estimatorSigmoidK * (sample - a.estimatorSigmoidX0)
+	if x < -50 {
+		return 1
+	} else if x > 50 {
+		return 0
+	}
+	return 1 / (1 + math.Exp(x))
+}
+
+func (a *VOCIndexAlgorithm) calculateGamma() {
+	uptimeLimit := vocMeanVarianceEstimatorFix16Max - a.samplingInterval
+	if a.estimatorUptimeGamma < uptimeLimit {
+		a.estimatorUptimeGamma += a.samplingInterval
+	}
+	if a.estimatorUptimeGating < uptimeLimit {
+		a.estimatorUptimeGating += a.samplingInterval
+	}
+
+	a.estimatorSigmoidX0, a.estimatorSigmoidK = vocInitDurationMean, vocInitTransitionMean
+	sigmoidGammaMean := a.estimatorSigmoid(a.estimatorUptimeGamma)
+	gammaMean := a.estimatorGammaMean + (a.estimatorGammaInitialMean-a.estimatorGammaMean)*sigmoidGammaMean
+	gatingThresholdMean := vocGatingThreshold +
+		(vocGatingThresholdInitial-vocGatingThreshold)*a.estimatorSigmoid(a.estimatorUptimeGating)
+	a.estimatorSigmoidX0, a.estimatorSigmoidK = gatingThresholdMean, vocGatingThresholdTransition
+	sigmoidGatingMean := a.estimatorSigmoid(a.gasIndex)
+	a.estimatorCurrentGammaMean = sigmoidGatingMean * gammaMean
+
+	a.estimatorSigmoidX0, a.estimatorSigmoidK = vocInitDurationVariance, vocInitTransitionVariance
+	sigmoidGammaVariance := a.estimatorSigmoid(a.estimatorUptimeGamma)
+	gammaVariance := a.estimatorGammaVariance + (a.estimatorGammaInitialVariance-a.estimatorGammaVariance)*
+		(sigmoidGammaVariance-sigmoidGammaMean)
+	gatingThresholdVariance := vocGatingThreshold +
+		(vocGatingThresholdInitial-vocGatingThreshold)*a.estimatorSigmoid(a.estimatorUptimeGating)
+	a.estimatorSigmoidX0, a.estimatorSigmoidK = gatingThresholdVariance, vocGatingThresholdTransition
+	sigmoidGatingVariance := a.estimatorSigmoid(a.gasIndex)
+	a.estimatorCurrentGammaVariance = sigmoidGatingVariance * gammaVariance
+
+	a.estimatorGatingMinutes += a.samplingInterval / 60 *
+		((1-sigmoidGatingMean)*(1+vocGatingMaxRatio) - vocGatingMaxRatio)
+	if a.estimatorGatingMinutes < 0 {
+		a.estimatorGatingMinutes = 0
+	}
+	if a.estimatorGatingMinutes > vocGatingMaxDurationMinutes {
+		a.estimatorUptimeGating = 0
+	}
+}
+
+// processEstimator updates the mean and standard deviation of the raw signal.
+func (a *VOCIndexAlgorithm) processEstimator(sraw float64) {
+	if !a.estimatorInitialized {
+		a.estimatorInitialized = true
+		a.estimatorSrawOffset = sraw
+		a.estimatorMean = 0
+		return
+	}
+	if a.estimatorMean >= 100 || a.estimatorMean <= -100 {
+		a.estimatorSrawOffset += a.estimatorMean
+		a.estimatorMean = 0
+	}
+	sraw -= a.estimatorSrawOffset
+	a.calculateGamma()
+	delta := (sraw - a.estimatorMean) / vocGammaScaling
+	c := a.estimatorStd + math.Abs(delta)
+	additionalScaling := 1.0
+	if c > 1440 {
+		additionalScaling = (c / 1440) * (c / 1440)
+	}
+	a.estimatorStd = math.Sqrt(additionalScaling*(vocGammaScaling-a.estimatorCurrentGammaVariance)) *
+		math.Sqrt(a.estimatorStd*(a.estimatorStd/(vocGammaScaling*additionalScaling))+
+			a.estimatorCurrentGammaVariance*delta/additionalScaling*delta)
+	a.estimatorMean += a.estimatorCurrentGammaMean * delta / vocAdditionalGammaMeanScaling
+}
+
+func (a *VOCIndexAlgorithm) moxModel(sraw float64) float64 {
+	return (sraw - a.moxSrawMean) / (-(a.moxSrawStd + vocSrawStdBonus)) * vocIndexGain
+}
+
+func (a *VOCIndexAlgorithm) sigmoidScaled(sample float64) float64 {
+	x := vocSigmoidK * (sample - vocSigmoidX0)
+	if x < -50 {
+		return vocSigmoidL
+	} else if x > 50 {
+		return 0
+	}
+	if sample >= 0 {
+		shift := (vocSigmoidL - 5*vocIndexOffset) / 4
+		return (vocSigmoidL+shift)/(1+math.Exp(x)) - shift
+	}
+	return vocSigmoidL / (1 + math.Exp(x))
+}
+
+func (a *VOCIndexAlgorithm) adaptiveLowpass(sample float64) float64 {
+	if !a.lowpassInitialized {
+		a.lowpassX1, a.lowpassX2, a.lowpassX3 = sample, sample, sample
+		a.lowpassInitialized = true
+	}
+	a.lowpassX1 = (1-a.lowpassA1)*a.lowpassX1 + a.lowpassA1*sample
+	a.lowpassX2 = (1-a.lowpassA2)*a.lowpassX2 + a.lowpassA2*sample
+	tau := (vocLowpassTauSlow-vocLowpassTauFast)*math.Exp(vocLowpassAlpha*math.Abs(a.lowpassX1-a.lowpassX2)) +
+		vocLowpassTauFast
+	a3 := a.samplingInterval / (a.samplingInterval + tau)
+	a.lowpassX3 = (1-a3)*a.lowpassX3 + a3*sample
+	return a.lowpassX3
+}
+
+// SGP40CompensationTicks converts the relative humidity in percent and the
+// temperature in Celsius from another sensor to the ticks that are passed to the
+// SGP40 and SGP41 measure command for humidity compensation of the raw signal.
+// Values outside of the sensor's range (0 % to 100 % and -45° C to 130° C) are
+// limited to it.
+func SGP40CompensationTicks(relativeHumidity float64, tempCelsius float64) (uint16, uint16) {
+	relativeHumidity = math.Max(0, math.Min(100, relativeHumidity))
+	tempCelsius = math.Max(-45, math.Min(130, tempCelsius))
+	return uint16(math.Round(relativeHumidity * 65535 / 100)), uint16(math.Round((tempCelsius + 45) * 65535 / 175))
+}
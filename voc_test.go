@@ -0,0 +1,92 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVOCIndexAlgorithmBlackout(t *testing.T) {
+	algorithm := NewVOCIndexAlgorithm(time.Second)
+	for i := 0; i < 45; i++ {
+		if index := algorithm.Process(30000); index != 0 {
+			t.Fatalf("VOC index for sample %d during blackout was incorrect, got: %d, want: 0.", i, index)
+		}
+	}
+}
+
+// runVOCIndexAlgorithm feeds the raw signals for the given durations in seconds
+// into the algorithm and returns the last VOC index.
+func runVOCIndexAlgorithm(algorithm *VOCIndexAlgorithm, sraw int, seconds int) int {
+	index := 0
+	for i := 0; i < seconds; i++ {
+		index = algorithm.Process(sraw)
+	}
+	return index
+}
+
+func TestVOCIndexAlgorithm(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseline int
+		sraw     int
+		seconds  int
+		minIndex int
+		maxIndex int
+	}{
+		{"constant", 30000, 30000, 600, 100, 100},
+		{"slightly more VOC", 30000, 29900, 60, 101, 200},
+		{"much more VOC", 30000, 28000, 300, 300, 500},
+		{"less VOC", 30000, 30300, 300, 1, 99},
+		{"below raw minimum", 30000, 1000, 600, 300, 500},
+		{"invalid raw signal", 30000, 0, 600, 100, 100},
+	}
+
+	for _, test := range tests {
+		algorithm := NewVOCIndexAlgorithm(time.Second)
+		runVOCIndexAlgorithm(algorithm, test.baseline, 3600)
+		index := runVOCIndexAlgorithm(algorithm, test.sraw, test.seconds)
+		if index < test.minIndex || index > test.maxIndex {
+			t.Errorf(
+				"VOC index for %s (%d ticks for %d s after 1 h at %d ticks) was incorrect, got: %d, want: %d to %d.",
+				test.name, test.sraw, test.seconds, test.baseline, index, test.minIndex, test.maxIndex)
+		}
+	}
+}
+
+func TestVOCIndexAlgorithmAdaption(t *testing.T) {
+	algorithm := NewVOCIndexAlgorithm(time.Second)
+	runVOCIndexAlgorithm(algorithm, 30000, 3600)
+	high := runVOCIndexAlgorithm(algorithm, 29500, 300)
+	adapted := runVOCIndexAlgorithm(algorithm, 29500, 48*3600)
+	if adapted >= high || adapted > 105 {
+		t.Errorf("VOC index did not adapt to a new baseline, got: %d after 5 min and %d after 48 h.",
+			high, adapted)
+	}
+}
+
+func TestSGP40CompensationTicks(t *testing.T) {
+	tests := []struct {
+		rh          float64
+		tempCelsius float64
+		rhTicks     uint16
+		tempTicks   uint16
+	}{
+		{50.0, 25.0, 0x8000, 0x6666},
+		{0.0, -45.0, 0, 0},
+		{100.0, 130.0, 0xffff, 0xffff},
+		{-5.0, -50.0, 0, 0},
+		{105.0, 140.0, 0xffff, 0xffff},
+	}
+
+	for _, test := range tests {
+		rhTicks, tempTicks := SGP40CompensationTicks(test.rh, test.tempCelsius)
+		if rhTicks != test.rhTicks || tempTicks != test.tempTicks {
+			t.Errorf(
+				"Compensation ticks for %f%% at %f° C were incorrect, got: 0x%04x, 0x%04x, want: 0x%04x, 0x%04x.",
+				test.rh, test.tempCelsius, rhTicks, tempTicks, test.rhTicks, test.tempTicks)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// WindVanePosition is the resistance in Ohm of a wind vane pointing in the
+// direction given in compass degrees.
+type WindVanePosition struct {
+	Degrees    float64
+	Resistance float64
+}
+
+// DefaultWindVanePositions are the 16 positions of the common reed switch wind
+// vane found in the SparkFun weather meter kit (SEN-15901) and similar kits.
+var DefaultWindVanePositions = []WindVanePosition{
+	{0.0, 33000}, {22.5, 6570}, {45.0, 8200}, {67.5, 891},
+	{90.0, 1000}, {112.5, 688}, {135.0, 2200}, {157.5, 1410},
+	{180.0, 3900}, {202.5, 3140}, {225.0, 16000}, {247.5, 14120},
+	{270.0, 120000}, {292.5, 42120}, {315.0, 64900}, {337.5, 21880},
+}
+
+// WindVane maps the voltage of a wind vane measured by an ADC to the wind direction.
+// The wind vane is connected as lower resistor of a voltage divider.
+type WindVane struct {
+	positions []WindVanePosition
+	voltages  []float64
+}
+
+// NewWindVane creates a wind vane for the given positions. The wind vane is
+// connected to ground and via the series resistor (in Ohm) to the reference
+// voltage. All resistances and the reference voltage have to be positive.
+func NewWindVane(positions []WindVanePosition, referenceVoltage float64, seriesResistance float64) (*WindVane, error) {
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("no wind vane positions specified")
+	}
+	if !(referenceVoltage > 0) {
+		return nil, fmt.Errorf("reference voltage %g V is not positive", referenceVoltage)
+	}
+	if !(seriesResistance > 0) {
+		return nil, fmt.Errorf("series resistance %g Ω is not positive", seriesResistance)
+	}
+	copied := make([]WindVanePosition, len(positions))
+	copy(copied, positions)
+	voltages := make([]float64, len(copied))
+	for i, position := range copied {
+		if !(position.Resistance > 0) {
+			return nil, fmt.Errorf(
+				"resistance %g Ω for %g° is not positive", position.Resistance, position.Degrees)
+		}
+		voltages[i] = referenceVoltage * position.Resistance / (position.Resistance + seriesResistance)
+	}
+	return &WindVane{positions: copied, voltages: voltages}, nil
+}
+
+// Direction returns the wind direction in compass degrees for the position whose
+// voltage is the closest to the measured voltage.
+func (v *WindVane) Direction(voltage float64) float64 {
+	nearest := 0
+	for i := range v.voltages {
+		if math.Abs(v.voltages[i]-voltage) < math.Abs(v.voltages[nearest]-voltage) {
+			nearest = i
+		}
+	}
+	return v.positions[nearest].Degrees
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2021, Benjamin Drung <bdrung@posteo.de>
+// SPDX-License-Identifier: ISC
+
+package main
+
+import "testing"
+
+func TestWindVaneDirection(t *testing.T) {
+	vane, err := NewWindVane(DefaultWindVanePositions, 5.0, 10000)
+	if err != nil {
+		t.Fatalf("Creating wind vane failed: %v", err)
+	}
+
+	tests := []struct {
+		voltage float64
+		degrees float64
+	}{
+		{3.84, 0.0},
+		{1.98, 22.5},
+		{2.25, 45.0},
+		{0.41, 67.5},
+		{0.45, 90.0},
+		{0.32, 112.5},
+		{0.90, 135.0},
+		{0.62, 157.5},
+		{1.40, 180.0},
+		{1.19, 202.5},
+		{3.08, 225.0},
+		{2.93, 247.5},
+		{4.62, 270.0},
+		{4.04, 292.5},
+		{4.34, 315.0},
+		{3.43, 337.5},
+	}
+
+	for _, test := range tests {
+		degrees := vane.Direction(test.voltage)
+		if degrees != test.degrees {
+			t.Errorf(
+				"Wind direction for %f V was incorrect, got: %f°, want: %f°.",
+				test.voltage, degrees, test.degrees)
+		}
+	}
+}
+
+func TestNewWindVaneCopiesPositions(t *testing.T) {
+	positions := []WindVanePosition{{0.0, 33000}, {180.0, 3900}}
+	vane, err := NewWindVane(positions, 5.0, 10000)
+	if err != nil {
+		t.Fatalf("Creating wind vane failed: %v", err)
+	}
+	positions[0].Degrees = 90.0
+	if degrees := vane.Direction(3.84); degrees != 0.0 {
+		t.Errorf("Wind direction changed with the passed positions, got: %f°, want: 0.000000°.", degrees)
+	}
+}
+
+func TestNewWindVaneInvalid(t *testing.T) {
+	tests := []struct {
+		positions        []WindVanePosition
+		referenceVoltage float64
+		seriesResistance float64
+	}{
+		{nil, 3.3, 10000},
+		{DefaultWindVanePositions, 3.3, 0},
+		{DefaultWindVanePositions, 3.3, -10000},
+		{DefaultWindVanePositions, 0, 10000},
+		{[]WindVanePosition{{0.0, 33000}, {180.0, 0}}, 3.3, 10000},
+		{[]WindVanePosition{{0.0, -33000}}, 3.3, 10000},
+	}
+
+	for _, test := range tests {
+		if _, err := NewWindVane(test.positions, test.referenceVoltage, test.seriesResistance); err == nil {
+			t.Errorf("Creating wind vane with %v at %f V and %f Ω did not fail.",
+				test.positions, test.referenceVoltage, test.seriesResistance)
+		}
+	}
+}